@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+// scriptedInput replays a fixed sequence of actions, then quits - for
+// driving Game deterministically in tests.
+type scriptedInput struct {
+	actions []Action
+	next    int
+}
+
+func (s *scriptedInput) Next() (Action, error) {
+	if s.next >= len(s.actions) {
+		return Action{Kind: ActionQuit}, nil
+	}
+	a := s.actions[s.next]
+	s.next++
+	return a, nil
+}
+
+// recordingRenderer captures every message Game sends it, discarding
+// renders - for asserting on the win/lose banner in tests.
+type recordingRenderer struct {
+	messages []string
+}
+
+func (r *recordingRenderer) Render(Grid) {}
+
+func (r *recordingRenderer) Message(msg string) {
+	r.messages = append(r.messages, msg)
+}
+
+func (r *recordingRenderer) last() string {
+	if len(r.messages) == 0 {
+		return ""
+	}
+	return r.messages[len(r.messages)-1]
+}
+
+func TestGameWinThresholdTracksMinesPlacedNotMinesRequested(t *testing.T) {
+	// A 3x3 grid with 6 requested mines whose first click is the center
+	// forbids all 9 cells, so placeMines ends up placing zero mines -
+	// the whole board cascades open on that single reveal. Game's win
+	// threshold must reflect that, not the originally requested count.
+	g, err := NewGrid(3, 3, 6, NewMines(6))
+	if err != nil {
+		t.Fatalf("NewGrid: %v", err)
+	}
+	renderer := &recordingRenderer{}
+	input := &scriptedInput{actions: []Action{{Coord: Coordinate{1, 1}, Kind: ActionReveal}}}
+
+	Game(g, renderer, input)
+
+	if got := g.revealedCount(); got != 9 {
+		t.Fatalf("revealedCount() = %d, want 9 (whole board)", got)
+	}
+	if !strings.Contains(renderer.last(), "WIN") {
+		t.Errorf("Game ended with %q, want the win banner", renderer.last())
+	}
+}
+
+func TestGameDoesNotDeclareWinBeforeAllSafeCellsAreRevealed(t *testing.T) {
+	// Custom mineFunc places fewer mines (2) than requested (5),
+	// mimicking what placeMines does when the forbidden first-click zone
+	// shrinks the candidate pool - Game's win threshold must track the
+	// reconciled count, not the original request. The layout leaves one
+	// safe cell, (1,4), past the cascade's boundary that needs its own
+	// explicit reveal.
+	twoMines := func(rows, cols int, forbidden map[Coordinate]bool) []Coordinate {
+		return []Coordinate{{0, 4}, {2, 4}}
+	}
+	g, err := NewGrid(3, 5, 5, twoMines)
+	if err != nil {
+		t.Fatalf("NewGrid: %v", err)
+	}
+	renderer := &recordingRenderer{}
+	input := &scriptedInput{actions: []Action{
+		{Coord: Coordinate{1, 1}, Kind: ActionReveal},
+		{Coord: Coordinate{1, 4}, Kind: ActionReveal},
+	}}
+
+	Game(g, renderer, input)
+
+	const safeCells = 3*5 - 2
+	if got := g.revealedCount(); got != safeCells {
+		t.Fatalf("revealedCount() = %d, want %d (all safe cells revealed before win is declared)", got, safeCells)
+	}
+	if !strings.Contains(renderer.last(), "WIN") {
+		t.Errorf("Game ended with %q, want the win banner", renderer.last())
+	}
+}
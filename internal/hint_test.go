@@ -0,0 +1,44 @@
+package internal
+
+import "testing"
+
+func TestHintIdentifiesForcedMine(t *testing.T) {
+	// 3x3 grid, single mine at (0,0): revealing the far corner cascades
+	// open every other field, leaving the mine as the only unrevealed
+	// neighbour of the "1" fields around it - the solver must name it.
+	g, err := NewGrid(3, 3, 1, fixedMines(Coordinate{0, 0}))
+	if err != nil {
+		t.Fatalf("NewGrid: %v", err)
+	}
+	if _, err := g.reveal(Coordinate{2, 2}); err != nil {
+		t.Fatalf("reveal: %v", err)
+	}
+	coord, reason, err := g.hint()
+	if err != nil {
+		t.Fatalf("hint: %v", err)
+	}
+	if coord != (Coordinate{0, 0}) || reason != "mine" {
+		t.Errorf("hint() = (%v, %q), want ({0 0}, \"mine\")", coord, reason)
+	}
+}
+
+func TestHintErrorsWithNoCertainMove(t *testing.T) {
+	g, err := NewGrid(3, 3, 1, fixedMines(Coordinate{0, 0}))
+	if err != nil {
+		t.Fatalf("NewGrid: %v", err)
+	}
+	if _, _, err := g.hint(); err == nil {
+		t.Error("hint() on a fully-unrevealed board should error, got a verdict")
+	}
+}
+
+func TestNewNoGuessGridProducesASolvableLayout(t *testing.T) {
+	g, err := NewNoGuessGrid(5, 5, 4, NewCoordinate(2, 2))
+	if err != nil {
+		t.Fatalf("NewNoGuessGrid: %v", err)
+	}
+	gr := g.(*grid)
+	if !gr.solvable() {
+		t.Error("NewNoGuessGrid returned a board the hint solver can't fully clear without guessing")
+	}
+}
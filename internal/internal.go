@@ -8,6 +8,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+
+	"github.com/caleb9/go-minesweeper/internal/solver"
 )
 
 type Grid interface {
@@ -16,9 +18,22 @@ type Grid interface {
 	mines() int
 	reveal(coord Coordinate) (isStillAlive bool, err error)
 	flag(coord Coordinate) error
+	revealedCount() int
+	hint() (coord Coordinate, reason string, err error)
+	field(coord Coordinate) FieldView
+	mineCoordinates() []Coordinate
 	revealAll(isVictory bool) string
 }
 
+// FieldView is the renderer-facing snapshot of a single field's state,
+// for frontends that need more than the stdout Stringer view.
+type FieldView struct {
+	IsMine        bool
+	IsRevealed    bool
+	IsFlagged     bool
+	AdjacentMines int
+}
+
 type field struct {
 	isMine        bool
 	isRevealed    bool
@@ -27,7 +42,11 @@ type field struct {
 }
 
 type grid struct {
-	fields [][]field
+	fields      [][]field
+	revealed    int
+	mineCount   int
+	mineFunc    MineFunc
+	minesPlaced bool
 }
 
 func (g *grid) rows() int {
@@ -42,15 +61,7 @@ func (g *grid) cols() int {
 }
 
 func (g *grid) mines() int {
-	mines := 0
-	for _, rowFields := range g.fields {
-		for _, field := range rowFields {
-			if field.isMine {
-				mines++
-			}
-		}
-	}
-	return mines
+	return g.mineCount
 }
 
 // reveal returns true if minesweeper is still alive, or an error
@@ -62,18 +73,98 @@ func (g *grid) reveal(coord Coordinate) (bool, error) {
 	if g.fields[row][col].isRevealed {
 		return false, errors.New("already defused")
 	}
-	g.fields[row][col].isRevealed = true
+	if !g.minesPlaced {
+		g.placeMines(coord)
+	}
+	g.revealField(row, col)
 	if g.fields[row][col].isMine {
 		return false, nil
 	}
-	for _, gridRow := range g.fields[max(row-1, 0):min(row+2, len(g.fields))] {
-		for _, field := range gridRow[max(col-1, 0):min(col+2, len(gridRow))] {
-			if field.isMine {
-				g.fields[row][col].adjacentMines++
+	if g.fields[row][col].adjacentMines == 0 {
+		g.cascade(row, col)
+	}
+	return true, nil
+}
+
+// revealField marks a single field as revealed, keeping the revealed
+// fields counter in sync.
+func (g *grid) revealField(row, col int) {
+	if g.fields[row][col].isRevealed {
+		return
+	}
+	g.fields[row][col].isRevealed = true
+	g.revealed++
+}
+
+// cascade uncovers the fields connected to (row, col) through zero-adjacency
+// cells, the standard minesweeper "flood fill". It stops expanding past
+// cells with adjacentMines > 0 and never touches flagged or mined fields.
+func (g *grid) cascade(row, col int) {
+	for r := max(row-1, 0); r < min(row+2, g.rows()); r++ {
+		for c := max(col-1, 0); c < min(col+2, g.cols()); c++ {
+			if r == row && c == col {
+				continue
+			}
+			neighbour := &g.fields[r][c]
+			if neighbour.isRevealed || neighbour.isFlagged || neighbour.isMine {
+				continue
+			}
+			g.revealField(r, c)
+			if neighbour.adjacentMines == 0 {
+				g.cascade(r, c)
 			}
 		}
 	}
-	return true, nil
+}
+
+func (g *grid) revealedCount() int {
+	return g.revealed
+}
+
+// placeMines lays out the mines on the first reveal of the game, excluding
+// firstClick and its neighbours so the player can never die on move one.
+// The forbidden zone can leave fewer free cells than mineCount asked for,
+// so mineCount is reconciled to however many mines the mineFunc actually
+// returned rather than trusting the original request.
+func (g *grid) placeMines(firstClick Coordinate) {
+	forbidden := make(map[Coordinate]bool, 9)
+	for r := max(firstClick.row-1, 0); r < min(firstClick.row+2, g.rows()); r++ {
+		for c := max(firstClick.col-1, 0); c < min(firstClick.col+2, g.cols()); c++ {
+			forbidden[Coordinate{r, c}] = true
+		}
+	}
+	mines := g.mineFunc(g.rows(), g.cols(), forbidden)
+	for _, mine := range mines {
+		g.fields[mine.row][mine.col].isMine = true
+	}
+	g.mineCount = len(mines)
+	g.computeAdjacentMines()
+	g.minesPlaced = true
+}
+
+// computeAdjacentMines precomputes the adjacentMines count for every
+// non-mine field, so reveal no longer has to do it lazily (and can tell
+// a zero-adjacency field apart from one it just hasn't visited yet).
+func (g *grid) computeAdjacentMines() {
+	for row := range g.fields {
+		for col := range g.fields[row] {
+			if g.fields[row][col].isMine {
+				continue
+			}
+			count := 0
+			for r := max(row-1, 0); r < min(row+2, g.rows()); r++ {
+				for c := max(col-1, 0); c < min(col+2, g.cols()); c++ {
+					if r == row && c == col {
+						continue
+					}
+					if g.fields[r][c].isMine {
+						count++
+					}
+				}
+			}
+			g.fields[row][col].adjacentMines = count
+		}
+	}
 }
 
 func (g *grid) flag(coord Coordinate) error {
@@ -85,6 +176,92 @@ func (g *grid) flag(coord Coordinate) error {
 	return nil
 }
 
+func (g *grid) field(coord Coordinate) FieldView {
+	f := g.fields[coord.row][coord.col]
+	return FieldView{
+		IsMine:        f.isMine,
+		IsRevealed:    f.isRevealed,
+		IsFlagged:     f.isFlagged,
+		AdjacentMines: f.adjacentMines,
+	}
+}
+
+// mineCoordinates returns the coordinates of every placed mine. Before
+// the first reveal (see placeMines) this is empty.
+func (g *grid) mineCoordinates() []Coordinate {
+	var mines []Coordinate
+	for row := range g.fields {
+		for col := range g.fields[row] {
+			if g.fields[row][col].isMine {
+				mines = append(mines, Coordinate{row, col})
+			}
+		}
+	}
+	return mines
+}
+
+// hint encodes the current board as a SAT problem - one boolean variable
+// per unrevealed, unflagged field, and one cardinality constraint per
+// revealed numbered field over its unrevealed neighbours - and looks for a
+// field whose mine status is already forced by those constraints. It
+// returns the first such field found, along with "safe" or "mine".
+func (g *grid) hint() (Coordinate, string, error) {
+	var border []Coordinate
+	varOf := make(map[Coordinate]int)
+	for row := range g.fields {
+		for col := range g.fields[row] {
+			f := g.fields[row][col]
+			if f.isRevealed || f.isFlagged {
+				continue
+			}
+			border = append(border, Coordinate{row, col})
+			varOf[Coordinate{row, col}] = len(border)
+		}
+	}
+
+	var clauses []solver.Clause
+	for row := range g.fields {
+		for col := range g.fields[row] {
+			f := g.fields[row][col]
+			if !f.isRevealed || f.adjacentMines == 0 {
+				continue
+			}
+			var neighbourVars []int
+			flagged := 0
+			for r := max(row-1, 0); r < min(row+2, g.rows()); r++ {
+				for c := max(col-1, 0); c < min(col+2, g.cols()); c++ {
+					if r == row && c == col {
+						continue
+					}
+					neighbour := g.fields[r][c]
+					switch {
+					case neighbour.isFlagged:
+						flagged++
+					case !neighbour.isRevealed:
+						neighbourVars = append(neighbourVars, varOf[Coordinate{r, c}])
+					}
+				}
+			}
+			target := f.adjacentMines - flagged
+			if len(neighbourVars) == 0 || target < 0 || target > len(neighbourVars) {
+				continue
+			}
+			clauses = append(clauses, solver.ExactlyK(neighbourVars, target)...)
+		}
+	}
+
+	for _, coord := range border {
+		v := varOf[coord]
+		if sat, _ := solver.Solve(append(clauses, solver.Clause{v}), len(border)); !sat {
+			return coord, "safe", nil
+		}
+		if sat, _ := solver.Solve(append(clauses, solver.Clause{-v}), len(border)); !sat {
+			return coord, "mine", nil
+		}
+	}
+	return Coordinate{}, "", errors.New("no certain move found")
+}
+
 func (g *grid) revealAll(isVictory bool) string {
 	for _, rowFields := range g.fields {
 		for _, field := range rowFields {
@@ -98,6 +275,11 @@ func (g *grid) revealAll(isVictory bool) string {
 }
 
 func (g *grid) String() string {
+	if glyphMax < g.rows() || glyphMax < g.cols() {
+		return fmt.Sprintf(
+			"grid is %dx%d, too large for this glyph-based view (max %dx%d); use the -tui frontend instead",
+			g.rows(), g.cols(), glyphMax, glyphMax)
+	}
 	var buffer strings.Builder
 	buffer.WriteString(" ")
 	for col := range g.fields[0] {
@@ -126,13 +308,15 @@ func (g *grid) String() string {
 	return buffer.String()
 }
 
-const (
-	colMax = 9
-	rowMax = 9
-)
+// glyphMax bounds the digit glyphs used by the stdout renderer's
+// row/column labels and number markers, which only exist as single
+// Unicode characters for 0-8. It is a rendering limit, not a grid one:
+// other Renderer implementations (e.g. the Bubble Tea TUI) aren't bound
+// by it and can draw grids of any size.
+const glyphMax = 9
 
 func rowColLabelGlyph(num int) (rune, error) {
-	if num < 0 || rowMax <= num || colMax <= num {
+	if num < 0 || glyphMax <= num {
 		return 0, errors.New("invalid num")
 	}
 	const One = int('\u2488')
@@ -140,7 +324,7 @@ func rowColLabelGlyph(num int) (rune, error) {
 }
 
 func adjacentMinesGlyph(num int) (rune, error) {
-	if num < 0 || rowMax <= num || colMax <= num {
+	if num < 0 || glyphMax <= num {
 		return 0, errors.New("invalid num")
 	}
 	const Zero = int('\uff10')
@@ -151,36 +335,110 @@ type Coordinate struct {
 	row, col int
 }
 
-func NewGrid(rows, cols int, mines []Coordinate) (Grid, error) {
-	const RowMin, ColMin = 3, 3
-	if rows < RowMin || rowMax < rows || cols < ColMin || colMax < cols {
+// NewCoordinate builds a Coordinate for callers outside this package,
+// such as main wiring up a fixed first click for NewNoGuessGrid.
+func NewCoordinate(row, col int) Coordinate {
+	return Coordinate{row, col}
+}
+
+// MineFunc lays mines out over a rows x cols grid, never placing one on a
+// forbidden coordinate. It is invoked once the player reveals their first
+// field, so the layout can be made to depend on that first click.
+type MineFunc func(rows, cols int, forbidden map[Coordinate]bool) []Coordinate
+
+func NewGrid(rows, cols, mineCount int, mineFunc MineFunc) (Grid, error) {
+	const RowMin, ColMin, RowMax, ColMax = 3, 3, 99, 99
+	if rows < RowMin || RowMax < rows || cols < ColMin || ColMax < cols {
 		return nil, errors.New("invalid grid size")
 	}
-	g := grid{make([][]field, rows)}
+	if mineCount < 0 || rows*cols < mineCount {
+		return nil, errors.New("invalid mine count")
+	}
+	g := grid{fields: make([][]field, rows), mineCount: mineCount, mineFunc: mineFunc}
 	for i := 0; i < rows; i++ {
 		g.fields[i] = make([]field, cols)
 	}
-	for _, mine := range mines {
-		row, col := mine.row, mine.col
-		if row < 0 || g.rows() <= row || col < 0 || g.cols() <= col {
-			return nil, errors.New("invalid mine")
+	return Grid(&g), nil
+}
+
+// NewMines returns a MineFunc that scatters count mines uniformly at
+// random over the fields not present in forbidden.
+func NewMines(count int) MineFunc {
+	return func(rows, cols int, forbidden map[Coordinate]bool) []Coordinate {
+		candidates := make([]Coordinate, 0, rows*cols)
+		for row := range rows {
+			for col := range cols {
+				coord := Coordinate{row, col}
+				if forbidden[coord] {
+					continue
+				}
+				candidates = append(candidates, coord)
+			}
+		}
+		rand.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+		if len(candidates) < count {
+			count = len(candidates)
 		}
-		g.fields[row][col].isMine = true
+		return candidates[0:count]
 	}
-	return Grid(&g), nil
 }
 
-func NewMines(rows, cols, count int) []Coordinate {
-	mines := make([]Coordinate, rows*cols)
-	for row := range rows {
-		for col := range cols {
-			mines[row*cols+col] = Coordinate{row, col}
+// NewNoGuessGrid generates mine layouts for a rows x cols grid with
+// mineCount mines until it finds one that the hint solver can fully clear
+// from firstReveal without ever needing to guess, and returns that grid
+// with firstReveal already played.
+func NewNoGuessGrid(rows, cols, mineCount int, firstReveal Coordinate) (Grid, error) {
+	const maxAttempts = 1000
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		candidate, err := NewGrid(rows, cols, mineCount, NewMines(mineCount))
+		if err != nil {
+			return nil, err
+		}
+		g := candidate.(*grid)
+		if _, err := g.reveal(firstReveal); err != nil {
+			return nil, err
+		}
+		mines := g.mineCoordinates()
+		if !g.solvable() {
+			continue
+		}
+		// solvable plays the whole board out on g, so hand back a fresh
+		// grid with the same layout and only firstReveal played.
+		fresh, err := NewGrid(rows, cols, len(mines), func(int, int, map[Coordinate]bool) []Coordinate { return mines })
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fresh.reveal(firstReveal); err != nil {
+			return nil, err
+		}
+		return fresh, nil
+	}
+	return nil, errors.New("could not generate a no-guess layout")
+}
+
+// solvable reports whether the remaining board can be fully cleared using
+// only moves the hint solver can prove, i.e. without ever guessing. It
+// plays those moves out on g itself.
+func (g *grid) solvable() bool {
+	noMineFields := g.rows()*g.cols() - g.mines()
+	for g.revealed < noMineFields {
+		coord, reason, err := g.hint()
+		if err != nil {
+			return false
+		}
+		if reason == "mine" {
+			if err := g.flag(coord); err != nil {
+				return false
+			}
+			continue
+		}
+		if _, err := g.reveal(coord); err != nil {
+			return false
 		}
 	}
-	rand.Shuffle(len(mines), func(i, j int) {
-		mines[i], mines[j] = mines[j], mines[i]
-	})
-	return mines[0:count]
+	return true
 }
 
 const Help = `
@@ -188,12 +446,89 @@ In each step, type ROW and COLUMN, confirm with [ENTER]
 
 To flag a mine, add 'f' at the end
 
+Type 'h' for a hint
+
 Examples:
 22  - defuse field in row 2 and column 2
-13f - flag field in row 1 and column 3 as mine`
+13f - flag field in row 1 and column 3 as mine
+h   - get a hint`
+
+// ActionKind identifies what a player wants to do next.
+type ActionKind int
+
+const (
+	ActionReveal ActionKind = iota
+	ActionFlag
+	ActionHint
+	ActionQuit
+)
+
+// Action is a single player move, as produced by an InputSource.
+type Action struct {
+	Coord Coordinate
+	Kind  ActionKind
+}
+
+// Renderer draws the grid and surfaces short status messages - errors,
+// hints, the win/lose banner - to the player. Game is agnostic to how
+// (or where) that happens.
+type Renderer interface {
+	Render(g Grid)
+	Message(msg string)
+}
+
+// InputSource produces the player's next Action.
+type InputSource interface {
+	Next() (Action, error)
+}
+
+// stdoutRenderer is the original println-based emoji board.
+type stdoutRenderer struct{}
+
+// NewStdoutRenderer returns the classic Renderer that prints the emoji
+// board straight to standard output.
+func NewStdoutRenderer() Renderer {
+	return stdoutRenderer{}
+}
+
+func (stdoutRenderer) Render(g Grid) {
+	fmt.Println()
+	fmt.Println(g)
+}
+
+func (stdoutRenderer) Message(msg string) {
+	fmt.Println(msg)
+}
+
+// scannerInputSource is the original stdin-driven controls: type a row
+// and column, optionally followed by 'f' to flag, or 'h' for a hint.
+type scannerInputSource struct {
+	scanner *bufio.Scanner
+}
+
+// NewScannerInputSource returns the classic stdin-driven InputSource.
+func NewScannerInputSource() InputSource {
+	return &scannerInputSource{bufio.NewScanner(os.Stdin)}
+}
+
+func (s *scannerInputSource) Next() (Action, error) {
+	coord, isFlag, isHint, err := readAndParseInput(s.scanner)
+	if err != nil {
+		return Action{}, err
+	}
+	switch {
+	case isHint:
+		return Action{Kind: ActionHint}, nil
+	case isFlag:
+		return Action{Coord: coord, Kind: ActionFlag}, nil
+	default:
+		return Action{Coord: coord, Kind: ActionReveal}, nil
+	}
+}
 
-// readAndParseInput returns user input field coordinate, whether it's a flag, or an error
-func readAndParseInput(inputScanner *bufio.Scanner) (Coordinate, bool, error) {
+// readAndParseInput returns user input field coordinate, whether it's a
+// flag, whether it's a hint request, or an error
+func readAndParseInput(inputScanner *bufio.Scanner) (Coordinate, bool, bool, error) {
 	fmt.Print("❓ ")
 	if !inputScanner.Scan() {
 		os.Exit(0)
@@ -201,61 +536,78 @@ func readAndParseInput(inputScanner *bufio.Scanner) (Coordinate, bool, error) {
 	err := inputScanner.Err()
 	zeroCoord, invalidInputErr := Coordinate{}, errors.New("invalid input")
 	if err != nil {
-		return zeroCoord, false, err
+		return zeroCoord, false, false, err
 	}
 	input := inputScanner.Text()
+	if input == "h" {
+		return zeroCoord, false, true, nil
+	}
 	if len(input) < 2 || 3 < len(input) {
-		return zeroCoord, false, invalidInputErr
+		return zeroCoord, false, false, invalidInputErr
 	}
 	row, rowErr := strconv.Atoi(input[0:1])
 	col, colErr := strconv.Atoi(input[1:2])
 	if rowErr != nil || colErr != nil {
-		return zeroCoord, false, invalidInputErr
+		return zeroCoord, false, false, invalidInputErr
 	}
 	var flag bool
 	if len(input) == 3 {
 		if input[2] != 'f' {
-			return zeroCoord, false, invalidInputErr
+			return zeroCoord, false, false, invalidInputErr
 		}
 		flag = true
 	}
 	/* Subtract 1 because rows and columns are labeled with 1-indexed sequence */
-	return Coordinate{row - 1, col - 1}, flag, nil
+	return Coordinate{row - 1, col - 1}, flag, false, nil
 }
 
-func Game(minefield Grid) {
-	isAlive, revealedFields := true, 0
-	noMineFields := minefield.rows()*minefield.cols() - minefield.mines()
-	inputScanner := bufio.NewScanner(os.Stdin)
-	for isAlive && revealedFields < noMineFields {
-		fmt.Println()
-		fmt.Println(minefield)
-		coord, flag, err := readAndParseInput(inputScanner)
+// Game is a pure controller: it knows nothing about stdout, emoji or
+// Bubble Tea, only how to drive a Grid from whatever Renderer and
+// InputSource it's given.
+func Game(minefield Grid, renderer Renderer, input InputSource) {
+	isAlive := true
+	// mines() can change once the first reveal places them (see
+	// placeMines), so the win threshold is recomputed every iteration
+	// rather than snapshotted before that happens.
+	noMineFields := func() int { return minefield.rows()*minefield.cols() - minefield.mines() }
+	for isAlive && minefield.revealedCount() < noMineFields() {
+		renderer.Render(minefield)
+		action, err := input.Next()
 		if err != nil {
-			fmt.Println(err)
-			fmt.Println(Help)
+			renderer.Message(err.Error())
+			renderer.Message(Help)
 			continue
 		}
-		if flag {
-			err = minefield.flag(coord)
+		switch action.Kind {
+		case ActionQuit:
+			return
+		case ActionHint:
+			hintCoord, reason, err := minefield.hint()
 			if err != nil {
-				fmt.Println(err)
-				fmt.Println(Help)
+				renderer.Message(err.Error())
+				continue
 			}
-			continue
-		}
-		isStillAlive, err := minefield.reveal(coord)
-		if err != nil {
-			fmt.Println(err)
-			continue
+			rowGlyph, _ := rowColLabelGlyph(hintCoord.row)
+			colGlyph, _ := rowColLabelGlyph(hintCoord.col)
+			renderer.Message(fmt.Sprintf("💡 %c%c is %s", rowGlyph, colGlyph, reason))
+		case ActionFlag:
+			if err := minefield.flag(action.Coord); err != nil {
+				renderer.Message(err.Error())
+				renderer.Message(Help)
+			}
+		case ActionReveal:
+			isStillAlive, err := minefield.reveal(action.Coord)
+			if err != nil {
+				renderer.Message(err.Error())
+				continue
+			}
+			isAlive = isStillAlive
 		}
-		isAlive = isStillAlive
-		revealedFields++
 	}
-	fmt.Printf("\n%v\n", minefield.revealAll(isAlive))
+	renderer.Message(fmt.Sprintf("\n%v\n", minefield.revealAll(isAlive)))
 	if isAlive {
-		fmt.Println("\n🥵 YOU WIN!")
+		renderer.Message("\n🥵 YOU WIN!")
 	} else {
-		fmt.Println("\nYOU DIE! 🪦")
+		renderer.Message("\nYOU DIE! 🪦")
 	}
 }
@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRevealCascadesThroughZeroAdjacencyCells(t *testing.T) {
+	// 3x3 grid, single mine at (0,0): every other field is either part of
+	// the zero-adjacency region or borders it, so revealing the opposite
+	// corner must cascade open every non-mine field.
+	g, err := NewGrid(3, 3, 1, fixedMines(Coordinate{0, 0}))
+	if err != nil {
+		t.Fatalf("NewGrid: %v", err)
+	}
+	if _, err := g.reveal(Coordinate{2, 2}); err != nil {
+		t.Fatalf("reveal: %v", err)
+	}
+	if got, want := g.revealedCount(), 8; got != want {
+		t.Errorf("revealedCount() = %d, want %d", got, want)
+	}
+}
+
+func TestStringRefusesToRenderPastGlyphCap(t *testing.T) {
+	// 10x10 exceeds the stdout renderer's 0-8 digit glyphs; it
+	// must fall back to a message instead of printing garbage runes.
+	g, err := NewGrid(10, 10, 1, fixedMines(Coordinate{0, 0}))
+	if err != nil {
+		t.Fatalf("NewGrid: %v", err)
+	}
+	s := fmt.Sprint(g)
+	if strings.Contains(s, "🟩") {
+		t.Errorf("String() rendered an oversized grid instead of refusing: %q", s)
+	}
+}
+
+func TestCascadeStopsAtFlaggedFields(t *testing.T) {
+	g, err := NewGrid(3, 3, 1, fixedMines(Coordinate{0, 0}))
+	if err != nil {
+		t.Fatalf("NewGrid: %v", err)
+	}
+	if err := g.flag(Coordinate{1, 1}); err != nil {
+		t.Fatalf("flag: %v", err)
+	}
+	if _, err := g.reveal(Coordinate{2, 2}); err != nil {
+		t.Fatalf("reveal: %v", err)
+	}
+	if fv := g.field(Coordinate{1, 1}); fv.IsRevealed {
+		t.Error("cascade revealed a flagged field")
+	}
+}
@@ -0,0 +1,259 @@
+// Package journal implements an append-only move log for a minesweeper
+// game: every record is length-prefixed and carries a CRC-32 checksum
+// chained from the previous record's checksum, the same framing etcd's
+// WAL uses. That chain is what lets Load tell a genuinely corrupted (or
+// simply partially written, mid-crash) trailing record apart from the
+// rest of a good log, and drop only that tail instead of the whole file.
+package journal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// Coordinate mirrors internal.Coordinate. It's redeclared here, rather
+// than imported, so this package has no dependency on the game package
+// it serves.
+type Coordinate struct {
+	Row, Col int
+}
+
+// RecordType tags each record so the format can grow new kinds of moves
+// without breaking replay of logs written by an older version.
+type RecordType byte
+
+const (
+	RecordInit RecordType = iota
+	RecordReveal
+	RecordFlag
+)
+
+// Record is one journal entry. Rows, Cols and Mines are only populated
+// for RecordInit; Coord is only populated for RecordReveal and
+// RecordFlag.
+type Record struct {
+	Type  RecordType
+	Rows  int
+	Cols  int
+	Mines []Coordinate
+	Coord Coordinate
+}
+
+// Journal appends records to a file, chaining each record's CRC-32 from
+// the one before it.
+type Journal struct {
+	file *os.File
+	crc  uint32
+}
+
+// Create starts a brand-new journal at path, discarding whatever is
+// already there - the journal for a freshly started game. Use Open
+// instead to keep recording into an existing one.
+func Create(path string) (*Journal, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{file: file}, nil
+}
+
+// Open appends to path, continuing its CRC chain from the last
+// well-formed record. Any trailing bytes left by a previous run that
+// crashed mid-write are truncated first, exactly as Load would skip
+// them on read.
+func Open(path string) (*Journal, error) {
+	crc, validLen, err := scan(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Truncate(path, validLen); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{file: file, crc: crc}, nil
+}
+
+// Close closes the underlying file.
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
+
+// WriteInit appends the board layout. It must be written before any
+// reveal record; flag records may precede it.
+func (j *Journal) WriteInit(rows, cols int, mines []Coordinate) error {
+	return j.write(Record{Type: RecordInit, Rows: rows, Cols: cols, Mines: mines})
+}
+
+// WriteReveal appends a reveal move.
+func (j *Journal) WriteReveal(coord Coordinate) error {
+	return j.write(Record{Type: RecordReveal, Coord: coord})
+}
+
+// WriteFlag appends a flag move.
+func (j *Journal) WriteFlag(coord Coordinate) error {
+	return j.write(Record{Type: RecordFlag, Coord: coord})
+}
+
+func (j *Journal) write(r Record) error {
+	payload := encode(r)
+	j.crc = crc32.Update(j.crc, crc32.IEEETable, payload)
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(header[4:8], j.crc)
+	if _, err := j.file.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err := j.file.Write(payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Load reads every well-formed record from path, in order. A trailing
+// record whose length or CRC don't check out - the signature of a
+// process that died mid-write - ends the read there rather than
+// reporting an error, so callers can resume or replay what's left.
+func Load(path string) ([]Record, error) {
+	var records []Record
+	err := forEachValidRecord(path, func(payload []byte) error {
+		record, err := decode(payload)
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	})
+	return records, err
+}
+
+// scan walks path's valid records and returns the CRC chain value and
+// byte length up to (and including) the last one, so Open can continue
+// appending from exactly that point.
+func scan(path string) (crc uint32, validLen int64, err error) {
+	err = forEachValidRecord(path, func(payload []byte) error {
+		crc = crc32.Update(crc, crc32.IEEETable, payload)
+		validLen += int64(len(payload)) + 8
+		return nil
+	})
+	return crc, validLen, err
+}
+
+// forEachValidRecord streams path's length-prefixed, CRC-chained records
+// to visit, stopping silently at the first short read or checksum
+// mismatch instead of returning an error for it.
+func forEachValidRecord(path string, visit func(payload []byte) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var crc uint32
+	for {
+		var header [8]byte
+		if _, err := io.ReadFull(reader, header[:]); err != nil {
+			return nil
+		}
+		length := binary.BigEndian.Uint32(header[0:4])
+		wantCRC := binary.BigEndian.Uint32(header[4:8])
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			return nil
+		}
+		gotCRC := crc32.Update(crc, crc32.IEEETable, payload)
+		if gotCRC != wantCRC {
+			return nil
+		}
+		if err := visit(payload); err != nil {
+			return nil
+		}
+		crc = gotCRC
+	}
+}
+
+func encode(r Record) []byte {
+	buf := []byte{byte(r.Type)}
+	switch r.Type {
+	case RecordInit:
+		buf = appendInt(buf, r.Rows)
+		buf = appendInt(buf, r.Cols)
+		buf = appendInt(buf, len(r.Mines))
+		for _, m := range r.Mines {
+			buf = appendInt(buf, m.Row)
+			buf = appendInt(buf, m.Col)
+		}
+	case RecordReveal, RecordFlag:
+		buf = appendInt(buf, r.Coord.Row)
+		buf = appendInt(buf, r.Coord.Col)
+	}
+	return buf
+}
+
+func decode(payload []byte) (Record, error) {
+	if len(payload) < 1 {
+		return Record{}, errors.New("empty journal record")
+	}
+	r := Record{Type: RecordType(payload[0])}
+	rest := payload[1:]
+	readInt := func() (int, error) {
+		if len(rest) < 4 {
+			return 0, errors.New("truncated journal record")
+		}
+		v := int(binary.BigEndian.Uint32(rest[:4]))
+		rest = rest[4:]
+		return v, nil
+	}
+	var err error
+	switch r.Type {
+	case RecordInit:
+		if r.Rows, err = readInt(); err != nil {
+			return Record{}, err
+		}
+		if r.Cols, err = readInt(); err != nil {
+			return Record{}, err
+		}
+		count, err := readInt()
+		if err != nil {
+			return Record{}, err
+		}
+		r.Mines = make([]Coordinate, count)
+		for i := range r.Mines {
+			row, err := readInt()
+			if err != nil {
+				return Record{}, err
+			}
+			col, err := readInt()
+			if err != nil {
+				return Record{}, err
+			}
+			r.Mines[i] = Coordinate{row, col}
+		}
+	case RecordReveal, RecordFlag:
+		row, err := readInt()
+		if err != nil {
+			return Record{}, err
+		}
+		col, err := readInt()
+		if err != nil {
+			return Record{}, err
+		}
+		r.Coord = Coordinate{row, col}
+	default:
+		return Record{}, errors.New("unknown journal record type")
+	}
+	return r, nil
+}
+
+func appendInt(buf []byte, v int) []byte {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(v))
+	return append(buf, b[:]...)
+}
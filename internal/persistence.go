@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/caleb9/go-minesweeper/internal/journal"
+)
+
+// journaledGrid wraps a Grid and mirrors every successful reveal/flag to
+// an append-only journal, so the game can later be resumed or replayed.
+type journaledGrid struct {
+	Grid
+	journal    *journal.Journal
+	initLogged bool
+}
+
+// String forwards to the wrapped Grid's own Stringer, since embedding
+// Grid only promotes the methods declared on the Grid interface itself.
+func (j *journaledGrid) String() string {
+	if s, ok := j.Grid.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return ""
+}
+
+func (j *journaledGrid) reveal(coord Coordinate) (bool, error) {
+	isAlive, err := j.Grid.reveal(coord)
+	if err != nil {
+		return isAlive, err
+	}
+	if !j.initLogged {
+		_ = j.journal.WriteInit(j.Grid.rows(), j.Grid.cols(), toJournalCoords(j.Grid.mineCoordinates()))
+		j.initLogged = true
+	}
+	_ = j.journal.WriteReveal(toJournalCoord(coord))
+	return isAlive, err
+}
+
+func (j *journaledGrid) flag(coord Coordinate) error {
+	if err := j.Grid.flag(coord); err != nil {
+		return err
+	}
+	return j.journal.WriteFlag(toJournalCoord(coord))
+}
+
+// SaveGame wires g to a brand-new append-only journal at path: every
+// subsequent reveal or flag is durably recorded so LoadGame can later
+// resume the game. Any journal already at path is discarded, since g is
+// a freshly started game, not a continuation of whatever was recorded
+// there before.
+func SaveGame(g Grid, path string) (Grid, error) {
+	j, err := journal.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &journaledGrid{Grid: g, journal: j}, nil
+}
+
+// LoadGame reconstructs the game recorded at path and wires it back to
+// the same journal, so play can resume from where it left off. Unlike
+// SaveGame, it continues the existing journal's CRC chain rather than
+// starting a new one, and marks its Init record already written so the
+// next move doesn't redundantly log the whole mine layout again.
+func LoadGame(path string) (Grid, error) {
+	g, err := replayJournal(path)
+	if err != nil {
+		return nil, err
+	}
+	j, err := journal.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &journaledGrid{Grid: g, journal: j, initLogged: true}, nil
+}
+
+// ReplayGame reconstructs the game recorded at path without wiring it
+// back to the journal - for watching a finished or in-progress game
+// play out, not for continuing it.
+func ReplayGame(path string) (Grid, error) {
+	return replayJournal(path)
+}
+
+func replayJournal(path string) (Grid, error) {
+	records, err := journal.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	// A flag placed before the first reveal is journaled ahead of Init,
+	// since the mine layout only exists once that reveal happens.
+	initAt := -1
+	for i, record := range records {
+		if record.Type == journal.RecordInit {
+			initAt = i
+			break
+		}
+	}
+	if initAt < 0 {
+		return nil, errors.New("journal has no recorded game")
+	}
+
+	init := records[initAt]
+	mines := fromJournalCoords(init.Mines)
+	fixedLayout := func(rows, cols int, forbidden map[Coordinate]bool) []Coordinate {
+		return mines
+	}
+	g, err := NewGrid(init.Rows, init.Cols, len(mines), fixedLayout)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, record := range records {
+		if i == initAt {
+			continue
+		}
+		coord := fromJournalCoord(record.Coord)
+		switch record.Type {
+		case journal.RecordReveal:
+			if _, err := g.reveal(coord); err != nil {
+				return nil, err
+			}
+		case journal.RecordFlag:
+			if err := g.flag(coord); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return g, nil
+}
+
+func toJournalCoord(c Coordinate) journal.Coordinate {
+	return journal.Coordinate{Row: c.row, Col: c.col}
+}
+
+func toJournalCoords(cs []Coordinate) []journal.Coordinate {
+	out := make([]journal.Coordinate, len(cs))
+	for i, c := range cs {
+		out[i] = toJournalCoord(c)
+	}
+	return out
+}
+
+func fromJournalCoord(c journal.Coordinate) Coordinate {
+	return Coordinate{row: c.Row, col: c.Col}
+}
+
+func fromJournalCoords(cs []journal.Coordinate) []Coordinate {
+	out := make([]Coordinate, len(cs))
+	for i, c := range cs {
+		out[i] = fromJournalCoord(c)
+	}
+	return out
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/caleb9/go-minesweeper/internal/journal"
+)
+
+// fixedMines returns a MineFunc that always places mines at coords,
+// ignoring the forbidden first-click zone - for tests that need a known
+// mine layout.
+func fixedMines(coords ...Coordinate) MineFunc {
+	return func(rows, cols int, forbidden map[Coordinate]bool) []Coordinate {
+		return coords
+	}
+}
+
+func TestSaveGameStartsAFreshJournalInsteadOfAppending(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "game.journal")
+
+	first, err := NewGrid(3, 3, 1, fixedMines(Coordinate{0, 0}))
+	if err != nil {
+		t.Fatalf("NewGrid: %v", err)
+	}
+	if first, err = SaveGame(first, path); err != nil {
+		t.Fatalf("SaveGame: %v", err)
+	}
+	if _, err := first.reveal(Coordinate{1, 2}); err != nil {
+		t.Fatalf("reveal: %v", err)
+	}
+
+	second, err := NewGrid(3, 3, 1, fixedMines(Coordinate{2, 2}))
+	if err != nil {
+		t.Fatalf("NewGrid: %v", err)
+	}
+	if second, err = SaveGame(second, path); err != nil {
+		t.Fatalf("SaveGame: %v", err)
+	}
+	if _, err := second.reveal(Coordinate{0, 0}); err != nil {
+		t.Fatalf("reveal: %v", err)
+	}
+
+	replayed, err := ReplayGame(path)
+	if err != nil {
+		t.Fatalf("ReplayGame: %v", err)
+	}
+	mines := replayed.mineCoordinates()
+	if len(mines) != 1 || mines[0] != (Coordinate{2, 2}) {
+		t.Errorf("ReplayGame replayed mines = %v, want only the second game's [{2 2}]", mines)
+	}
+}
+
+func TestLoadGameDoesNotRewriteInitRecordOnResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "game.journal")
+
+	g, err := NewGrid(3, 3, 1, fixedMines(Coordinate{0, 0}))
+	if err != nil {
+		t.Fatalf("NewGrid: %v", err)
+	}
+	if g, err = SaveGame(g, path); err != nil {
+		t.Fatalf("SaveGame: %v", err)
+	}
+	if _, err := g.reveal(Coordinate{1, 2}); err != nil {
+		t.Fatalf("reveal: %v", err)
+	}
+
+	resumed, err := LoadGame(path)
+	if err != nil {
+		t.Fatalf("LoadGame: %v", err)
+	}
+	if err := resumed.flag(Coordinate{0, 0}); err != nil {
+		t.Fatalf("flag: %v", err)
+	}
+
+	records, err := journal.Load(path)
+	if err != nil {
+		t.Fatalf("journal.Load: %v", err)
+	}
+	inits := 0
+	for _, r := range records {
+		if r.Type == journal.RecordInit {
+			inits++
+		}
+	}
+	if inits != 1 {
+		t.Errorf("journal has %d Init records after resume, want 1", inits)
+	}
+}
@@ -0,0 +1,76 @@
+package solver
+
+// ExactlyK encodes "exactly k of vars are true" as CNF clauses. It is
+// meant for small neighbourhoods (a minesweeper cell has at most 8
+// neighbours), since the encoding is a direct combinatorial one rather
+// than a sequential counter.
+func ExactlyK(vars []int, k int) []Clause {
+	clauses := atMostK(vars, k)
+	return append(clauses, atLeastK(vars, k)...)
+}
+
+// atMostK forbids every combination of k+1 vars from being true at once.
+func atMostK(vars []int, k int) []Clause {
+	if k >= len(vars) {
+		return nil
+	}
+	var clauses []Clause
+	forEachCombination(vars, k+1, func(subset []int) {
+		clause := make(Clause, len(subset))
+		for i, v := range subset {
+			clause[i] = -v
+		}
+		clauses = append(clauses, clause)
+	})
+	return clauses
+}
+
+// atLeastK forbids every combination of len(vars)-k+1 vars from being
+// false at once, i.e. requires at least k of vars to be true.
+func atLeastK(vars []int, k int) []Clause {
+	if k <= 0 {
+		return nil
+	}
+	need := len(vars) - k + 1
+	if need > len(vars) {
+		return nil
+	}
+	var clauses []Clause
+	forEachCombination(vars, need, func(subset []int) {
+		clause := make(Clause, len(subset))
+		copy(clause, subset)
+		clauses = append(clauses, clause)
+	})
+	return clauses
+}
+
+// forEachCombination visits every size-length subset of items, in
+// increasing lexicographic order of indices.
+func forEachCombination(items []int, size int, visit func(subset []int)) {
+	if size <= 0 || size > len(items) {
+		return
+	}
+	indices := make([]int, size)
+	for i := range indices {
+		indices[i] = i
+	}
+	for {
+		subset := make([]int, size)
+		for i, idx := range indices {
+			subset[i] = items[idx]
+		}
+		visit(subset)
+
+		i := size - 1
+		for i >= 0 && indices[i] == len(items)-size+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		indices[i]++
+		for j := i + 1; j < size; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+	}
+}
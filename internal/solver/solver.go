@@ -0,0 +1,118 @@
+// Package solver implements a small boolean satisfiability (SAT) solver.
+// It is used by the minesweeper hint system: each unrevealed border cell
+// becomes a boolean variable (true meaning "is a mine"), each numbered
+// revealed cell contributes a cardinality constraint over its unrevealed
+// neighbours, and the solver is asked whether forcing a variable one way
+// or the other still admits a solution.
+package solver
+
+// Clause is a disjunction of literals. A literal is a non-zero variable
+// number; a negative value denotes the negation of that variable.
+type Clause []int
+
+// Solve runs a DPLL-style search and reports whether clauses is
+// satisfiable over variables numbered 1..numVars, returning one
+// satisfying assignment when it is.
+func Solve(clauses []Clause, numVars int) (sat bool, assignment map[int]bool) {
+	assignment = make(map[int]bool, numVars)
+	if search(clauses, assignment, numVars) {
+		return true, assignment
+	}
+	return false, nil
+}
+
+func search(clauses []Clause, assignment map[int]bool, numVars int) bool {
+	for {
+		unit, hasUnit, conflict := propagateOnce(clauses, assignment)
+		if conflict {
+			return false
+		}
+		if !hasUnit {
+			break
+		}
+		v, value := unit, true
+		if v < 0 {
+			v, value = -v, false
+		}
+		assignment[v] = value
+	}
+
+	branchVar, found := firstUnassigned(assignment, numVars)
+	if !found {
+		return true
+	}
+	for _, value := range [2]bool{true, false} {
+		trial := cloneAssignment(assignment)
+		trial[branchVar] = value
+		if search(clauses, trial, numVars) {
+			for v, val := range trial {
+				assignment[v] = val
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// propagateOnce scans clauses once for a conflict (every literal false) or
+// a unit clause (exactly one literal left unassigned, forcing its value).
+func propagateOnce(clauses []Clause, assignment map[int]bool) (unit int, hasUnit, conflict bool) {
+	for _, c := range clauses {
+		satisfied, forced, isConflict := status(c, assignment)
+		if isConflict {
+			return 0, false, true
+		}
+		if !satisfied && forced != 0 {
+			return forced, true, false
+		}
+	}
+	return 0, false, false
+}
+
+// status evaluates a clause under a partial assignment: satisfied if any
+// literal is already true, a conflict if every literal is false, or
+// forced to the one remaining unassigned literal if only one is left.
+func status(c Clause, assignment map[int]bool) (satisfied bool, forced int, conflict bool) {
+	unassigned := 0
+	var last int
+	for _, lit := range c {
+		v := lit
+		if v < 0 {
+			v = -v
+		}
+		value, isAssigned := assignment[v]
+		if !isAssigned {
+			unassigned++
+			last = lit
+			continue
+		}
+		if value == (lit > 0) {
+			return true, 0, false
+		}
+	}
+	switch unassigned {
+	case 0:
+		return false, 0, true
+	case 1:
+		return false, last, false
+	default:
+		return false, 0, false
+	}
+}
+
+func firstUnassigned(assignment map[int]bool, numVars int) (int, bool) {
+	for v := 1; v <= numVars; v++ {
+		if _, ok := assignment[v]; !ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+func cloneAssignment(a map[int]bool) map[int]bool {
+	c := make(map[int]bool, len(a))
+	for v, value := range a {
+		c[v] = value
+	}
+	return c
+}
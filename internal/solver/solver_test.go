@@ -0,0 +1,53 @@
+package solver
+
+import "testing"
+
+func TestSolveSatisfiable(t *testing.T) {
+	// (x1 OR x2) AND (NOT x1 OR x2) is only satisfiable with x2 true.
+	sat, assignment := Solve([]Clause{{1, 2}, {-1, 2}}, 2)
+	if !sat {
+		t.Fatal("expected satisfiable")
+	}
+	if !assignment[2] {
+		t.Errorf("assignment = %v, want var 2 forced true", assignment)
+	}
+}
+
+func TestSolveUnsatisfiable(t *testing.T) {
+	sat, _ := Solve([]Clause{{1}, {-1}}, 1)
+	if sat {
+		t.Fatal("expected unsatisfiable")
+	}
+}
+
+func TestExactlyKForcesAllTrueWhenKEqualsLen(t *testing.T) {
+	sat, assignment := Solve(ExactlyK([]int{1, 2, 3}, 3), 3)
+	if !sat {
+		t.Fatal("expected satisfiable")
+	}
+	for _, v := range []int{1, 2, 3} {
+		if !assignment[v] {
+			t.Errorf("var %d = false, want true", v)
+		}
+	}
+}
+
+func TestExactlyKForcesAllFalseWhenKIsZero(t *testing.T) {
+	sat, assignment := Solve(ExactlyK([]int{1, 2, 3}, 0), 3)
+	if !sat {
+		t.Fatal("expected satisfiable")
+	}
+	for _, v := range []int{1, 2, 3} {
+		if assignment[v] {
+			t.Errorf("var %d = true, want false", v)
+		}
+	}
+}
+
+func TestExactlyKRejectsTooManyTrue(t *testing.T) {
+	clauses := ExactlyK([]int{1, 2, 3}, 1)
+	clauses = append(clauses, Clause{1}, Clause{2}) // force two of three true
+	if sat, _ := Solve(clauses, 3); sat {
+		t.Fatal("expected unsatisfiable: two vars forced true but exactly 1 required")
+	}
+}
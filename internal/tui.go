@@ -0,0 +1,194 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// teaFrontend is a Renderer and InputSource backed by a Bubble Tea
+// program: arrow keys move the cursor, space reveals, 'f' flags and 'h'
+// asks for a hint. It bridges the synchronous Renderer/InputSource pair
+// Game expects onto Bubble Tea's async Update/View event loop.
+type teaFrontend struct {
+	program *tea.Program
+	actions chan Action
+	done    chan struct{}
+}
+
+// NewTeaFrontend starts the Bubble Tea program in the background and
+// returns the Renderer/InputSource pair Game drives it through.
+func NewTeaFrontend() *teaFrontend {
+	f := &teaFrontend{actions: make(chan Action), done: make(chan struct{})}
+	f.program = tea.NewProgram(newTeaModel(f.actions))
+	go func() {
+		_, _ = f.program.Run()
+		close(f.done)
+	}()
+	return f
+}
+
+// Wait blocks until the Bubble Tea program has actually finished
+// rendering and exited (the player quit it), so a caller doesn't race
+// the rendering goroutine by exiting the process out from under it -
+// e.g. right after Game sends the final win/lose message.
+func (f *teaFrontend) Wait() {
+	<-f.done
+}
+
+func (f *teaFrontend) Render(g Grid) {
+	f.program.Send(gridMsg{g})
+}
+
+func (f *teaFrontend) Message(msg string) {
+	f.program.Send(messageMsg(msg))
+}
+
+func (f *teaFrontend) Next() (Action, error) {
+	return <-f.actions, nil
+}
+
+type gridMsg struct{ grid Grid }
+
+type messageMsg string
+
+type tickMsg time.Time
+
+type teaModel struct {
+	actions chan<- Action
+	grid    Grid
+	cursor  Coordinate
+	message string
+	started time.Time
+	elapsed time.Duration
+}
+
+func newTeaModel(actions chan<- Action) teaModel {
+	return teaModel{actions: actions, started: time.Now()}
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+func (m teaModel) Init() tea.Cmd {
+	return tick()
+}
+
+func (m teaModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case gridMsg:
+		// Game re-renders right after every action, including a hint
+		// request, which doesn't itself change the grid - don't let that
+		// immediate re-render wipe a message before the player can read
+		// it. Messages are cleared when the player acts instead, in
+		// handleKey.
+		m.grid = msg.grid
+		return m, nil
+	case messageMsg:
+		m.message = string(msg)
+		return m, nil
+	case tickMsg:
+		m.elapsed = time.Since(m.started)
+		return m, tick()
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m teaModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up":
+		if m.cursor.row > 0 {
+			m.cursor.row--
+		}
+	case "down":
+		if m.grid != nil && m.cursor.row < m.grid.rows()-1 {
+			m.cursor.row++
+		}
+	case "left":
+		if m.cursor.col > 0 {
+			m.cursor.col--
+		}
+	case "right":
+		if m.grid != nil && m.cursor.col < m.grid.cols()-1 {
+			m.cursor.col++
+		}
+	case " ":
+		m.message = ""
+		m.actions <- Action{Coord: m.cursor, Kind: ActionReveal}
+	case "f":
+		m.message = ""
+		m.actions <- Action{Coord: m.cursor, Kind: ActionFlag}
+	case "h":
+		m.message = ""
+		m.actions <- Action{Kind: ActionHint}
+	case "q", "ctrl+c", "esc":
+		m.actions <- Action{Kind: ActionQuit}
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+var (
+	statusBarStyle = lipgloss.NewStyle().Bold(true).Padding(0, 1)
+	cursorStyle    = lipgloss.NewStyle().Reverse(true)
+	numberColors   = map[int]lipgloss.Color{
+		1: lipgloss.Color("4"),
+		2: lipgloss.Color("2"),
+		3: lipgloss.Color("1"),
+		4: lipgloss.Color("5"),
+		5: lipgloss.Color("3"),
+		6: lipgloss.Color("6"),
+		7: lipgloss.Color("0"),
+		8: lipgloss.Color("8"),
+	}
+)
+
+func (m teaModel) View() string {
+	if m.grid == nil {
+		return "starting up...\n"
+	}
+	var b strings.Builder
+	b.WriteString(statusBarStyle.Render(fmt.Sprintf(
+		"mines: %d   time: %s", m.grid.mines(), m.elapsed.Truncate(time.Second))))
+	b.WriteString("\n\n")
+	for row := 0; row < m.grid.rows(); row++ {
+		for col := 0; col < m.grid.cols(); col++ {
+			coord := Coordinate{row, col}
+			style, glyph := cellView(m.grid.field(coord))
+			if coord == m.cursor {
+				style = cursorStyle
+			}
+			b.WriteString(style.Render(glyph))
+		}
+		b.WriteString("\n")
+	}
+	if m.message != "" {
+		b.WriteString("\n" + m.message + "\n")
+	}
+	b.WriteString("\narrows move, space reveals, f flags, h hints, q quits\n")
+	return b.String()
+}
+
+func cellView(f FieldView) (lipgloss.Style, string) {
+	switch {
+	case f.IsFlagged:
+		return lipgloss.NewStyle(), " F "
+	case !f.IsRevealed:
+		return lipgloss.NewStyle(), " . "
+	case f.IsMine:
+		return lipgloss.NewStyle(), " * "
+	case f.AdjacentMines == 0:
+		return lipgloss.NewStyle(), "   "
+	default:
+		return lipgloss.NewStyle().Foreground(numberColors[f.AdjacentMines]),
+			fmt.Sprintf(" %d ", f.AdjacentMines)
+	}
+}
@@ -1,11 +1,58 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+
 	. "github.com/caleb9/go-minesweeper/internal"
 )
 
 func main() {
+	useTUI := flag.Bool("tui", false, "use the Bubble Tea terminal UI instead of the classic prompt")
+	noGuess := flag.Bool("no-guess", false, "generate a board the hint solver can fully clear from the first click without ever guessing")
+	savePath := flag.String("save", "minesweeper.journal", "append-only journal file this game's moves are recorded to")
+	resumePath := flag.String("resume", "", "resume an in-progress game from a journal file instead of starting a new one")
+	replayPath := flag.String("replay", "", "replay a finished or in-progress game from a journal file and exit")
+	flag.Parse()
+
+	if *replayPath != "" {
+		minefield, err := ReplayGame(*replayPath)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(minefield)
+		return
+	}
+
+	var minefield Grid
+	var err error
+	if *resumePath != "" {
+		minefield, err = LoadGame(*resumePath)
+	} else {
+		rows, cols := 6, 6
+		minesCount := 6
+		if *noGuess {
+			minefield, err = NewNoGuessGrid(rows, cols, minesCount, NewCoordinate(rows/2, cols/2))
+		} else {
+			minefield, err = NewGrid(rows, cols, minesCount, NewMines(minesCount))
+		}
+		if err == nil {
+			minefield, err = SaveGame(minefield, *savePath)
+		}
+	}
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if *useTUI {
+		frontend := NewTeaFrontend()
+		Game(minefield, frontend, frontend)
+		frontend.Wait()
+		return
+	}
+
 	fmt.Println(Help)
 	fmt.Println()
 	fmt.Println(`
@@ -13,9 +60,5 @@ Secret Service reports that there are 6 mines on that meadow... but where?
 Uncover all non-mine fields before someone steps on a wrong one. Beware though!
 Minesweeper's first mistake is also their last...`)
 	fmt.Println()
-
-	rows, cols := 6, 6
-	minesCount := 6
-	minefield, _ := NewGrid(rows, cols, NewMines(rows, cols, minesCount))
-	Game(minefield)
+	Game(minefield, NewStdoutRenderer(), NewScannerInputSource())
 }